@@ -0,0 +1,221 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	gloov1 "github.com/weaveworks/flagger/pkg/apis/gloo/v1"
+)
+
+// RouteTable is a gateway.solo.io/v1 RouteTable. Flagger programs one per
+// apex service when the canary needs routing features (header/cookie
+// matching, mirroring, retries, CORS, ...) that a bare UpstreamGroup
+// cannot express.
+type RouteTable struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RouteTableSpec `json:"spec"`
+}
+
+// RouteTableList is a list of RouteTable resources
+type RouteTableList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []RouteTable `json:"items"`
+}
+
+// RouteTableSpec holds the ordered list of routes evaluated for the apex
+// service. The first matching route wins.
+type RouteTableSpec struct {
+	Routes []Route `json:"routes,omitempty"`
+}
+
+// Route pairs a set of matchers with the action taken when they match. A
+// Route with no Matchers is a catch-all fallback.
+type Route struct {
+	Matchers []Matcher    `json:"matchers,omitempty"`
+	Action   *RouteAction `json:"routeAction,omitempty"`
+}
+
+// Matcher mirrors Gloo's HTTP matcher, restricted to the header
+// conditions Flagger needs for A/B testing. Gloo has no separate cookie
+// matcher either: a cookie condition is just a header match on Cookie.
+type Matcher struct {
+	Headers []HeaderMatcher `json:"headers,omitempty"`
+}
+
+// HeaderMatcher matches a request header, exactly or by regex.
+type HeaderMatcher struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+	Regex bool   `json:"regex,omitempty"`
+}
+
+// RouteAction is the destination programmed for a Route.
+type RouteAction struct {
+	Destination *MultiDestination   `json:"multi,omitempty"`
+	Options     *RouteActionOptions `json:"options,omitempty"`
+}
+
+// MultiDestination weights traffic across one or more upstreams, reusing
+// the same destination type UpstreamGroups use so the two can share
+// weight bookkeeping helpers.
+type MultiDestination struct {
+	Destinations []gloov1.WeightedDestination `json:"destinations,omitempty"`
+}
+
+// RouteActionOptions holds the features UpstreamGroup can't express and
+// that therefore require a RouteTable.
+type RouteActionOptions struct {
+	Shadowing          *ShadowPolicy       `json:"shadowing,omitempty"`
+	Retries            *RetryPolicy        `json:"retries,omitempty"`
+	Timeout            string              `json:"timeout,omitempty"`
+	Cors               *CorsPolicy         `json:"cors,omitempty"`
+	HeaderManipulation *HeaderManipulation `json:"headerManipulation,omitempty"`
+}
+
+// ShadowPolicy mirrors a percentage of the route's traffic to another
+// upstream without waiting for or acting on its response.
+type ShadowPolicy struct {
+	Upstream   gloov1.ResourceRef `json:"upstream"`
+	Percentage float64            `json:"percentage"`
+}
+
+// RetryPolicy controls how a route is retried on failure.
+type RetryPolicy struct {
+	NumRetries    uint32 `json:"numRetries,omitempty"`
+	PerTryTimeout string `json:"perTryTimeout,omitempty"`
+	RetryOn       string `json:"retryOn,omitempty"`
+}
+
+// CorsPolicy controls the CORS headers a route responds with.
+type CorsPolicy struct {
+	AllowOrigin      []string `json:"allowOrigin,omitempty"`
+	AllowMethods     []string `json:"allowMethods,omitempty"`
+	AllowHeaders     []string `json:"allowHeaders,omitempty"`
+	AllowCredentials bool     `json:"allowCredentials,omitempty"`
+	MaxAge           string   `json:"maxAge,omitempty"`
+}
+
+// HeaderManipulation adds or strips headers on the request/response path.
+type HeaderManipulation struct {
+	RequestHeadersToAdd     map[string]string `json:"requestHeadersToAdd,omitempty"`
+	RequestHeadersToRemove  []string          `json:"requestHeadersToRemove,omitempty"`
+	ResponseHeadersToAdd    map[string]string `json:"responseHeadersToAdd,omitempty"`
+	ResponseHeadersToRemove []string          `json:"responseHeadersToRemove,omitempty"`
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *RouteTable) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteTable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *RouteTableList) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteTableList)
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		out.Items = make([]RouteTable, len(in.Items))
+		for i := range in.Items {
+			in.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+	return out
+}
+
+// DeepCopyInto copies in into out.
+func (in *RouteTable) DeepCopyInto(out *RouteTable) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Spec.Routes != nil {
+		out.Spec.Routes = make([]Route, len(in.Spec.Routes))
+		for i := range in.Spec.Routes {
+			in.Spec.Routes[i].DeepCopyInto(&out.Spec.Routes[i])
+		}
+	}
+}
+
+// DeepCopyInto copies in into out.
+func (in *Route) DeepCopyInto(out *Route) {
+	*out = *in
+	if in.Matchers != nil {
+		out.Matchers = make([]Matcher, len(in.Matchers))
+		for i := range in.Matchers {
+			in.Matchers[i].DeepCopyInto(&out.Matchers[i])
+		}
+	}
+	if in.Action != nil {
+		action := new(RouteAction)
+		in.Action.DeepCopyInto(action)
+		out.Action = action
+	}
+}
+
+// DeepCopyInto copies in into out.
+func (in *Matcher) DeepCopyInto(out *Matcher) {
+	*out = *in
+	if in.Headers != nil {
+		out.Headers = make([]HeaderMatcher, len(in.Headers))
+		copy(out.Headers, in.Headers)
+	}
+}
+
+// DeepCopyInto copies in into out.
+func (in *RouteAction) DeepCopyInto(out *RouteAction) {
+	*out = *in
+	if in.Destination != nil {
+		dest := new(MultiDestination)
+		dest.Destinations = append([]gloov1.WeightedDestination(nil), in.Destination.Destinations...)
+		out.Destination = dest
+	}
+	if in.Options != nil {
+		opts := *in.Options
+		if in.Options.Shadowing != nil {
+			shadow := *in.Options.Shadowing
+			opts.Shadowing = &shadow
+		}
+		if in.Options.Retries != nil {
+			retries := *in.Options.Retries
+			opts.Retries = &retries
+		}
+		if in.Options.Cors != nil {
+			cors := *in.Options.Cors
+			cors.AllowOrigin = append([]string(nil), in.Options.Cors.AllowOrigin...)
+			cors.AllowMethods = append([]string(nil), in.Options.Cors.AllowMethods...)
+			cors.AllowHeaders = append([]string(nil), in.Options.Cors.AllowHeaders...)
+			opts.Cors = &cors
+		}
+		if in.Options.HeaderManipulation != nil {
+			hm := *in.Options.HeaderManipulation
+			hm.RequestHeadersToAdd = copyStringMap(in.Options.HeaderManipulation.RequestHeadersToAdd)
+			hm.ResponseHeadersToAdd = copyStringMap(in.Options.HeaderManipulation.ResponseHeadersToAdd)
+			hm.RequestHeadersToRemove = append([]string(nil), in.Options.HeaderManipulation.RequestHeadersToRemove...)
+			hm.ResponseHeadersToRemove = append([]string(nil), in.Options.HeaderManipulation.ResponseHeadersToRemove...)
+			opts.HeaderManipulation = &hm
+		}
+		out.Options = &opts
+	}
+}
+
+func copyStringMap(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
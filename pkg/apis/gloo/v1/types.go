@@ -0,0 +1,81 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// UpstreamGroup is a gloo.solo.io/v1 UpstreamGroup, weighting traffic
+// across a set of upstreams.
+type UpstreamGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec UpstreamGroupSpec `json:"spec"`
+}
+
+// UpstreamGroupList is a list of UpstreamGroup resources
+type UpstreamGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []UpstreamGroup `json:"items"`
+}
+
+// UpstreamGroupSpec holds the weighted destinations an UpstreamGroup
+// splits traffic across.
+type UpstreamGroupSpec struct {
+	Destinations []WeightedDestination `json:"destinations,omitempty"`
+}
+
+// WeightedDestination is an upstream with a weight out of 1000, Gloo's
+// routing weight base value.
+type WeightedDestination struct {
+	Destination Destination `json:"destination"`
+	Weight      uint32      `json:"weight"`
+}
+
+// Destination references the upstream a route or weighted destination
+// points to.
+type Destination struct {
+	Upstream ResourceRef `json:"upstream"`
+}
+
+// ResourceRef identifies a Gloo resource by name and namespace.
+type ResourceRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *UpstreamGroup) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamGroup)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Spec.Destinations != nil {
+		out.Spec.Destinations = make([]WeightedDestination, len(in.Spec.Destinations))
+		copy(out.Spec.Destinations, in.Spec.Destinations)
+	}
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *UpstreamGroupList) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamGroupList)
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		out.Items = make([]UpstreamGroup, len(in.Items))
+		for i := range in.Items {
+			item := in.Items[i].DeepCopyObject().(*UpstreamGroup)
+			out.Items[i] = *item
+		}
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Upstream is a gloo.solo.io/v1 Upstream, describing where Gloo should
+// route requests that reach it. Flagger only ever programs the `kube`
+// upstream spec, pointing at a ClusterIP service.
+type Upstream struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec UpstreamSpec `json:"spec"`
+}
+
+// UpstreamList is a list of Upstream resources
+type UpstreamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Upstream `json:"items"`
+}
+
+// UpstreamSpec holds the upstream type. Flagger only sets Kube.
+type UpstreamSpec struct {
+	Kube *KubeUpstreamSpec `json:"kube,omitempty"`
+}
+
+// KubeUpstreamSpec points an Upstream at a Kubernetes service.
+type KubeUpstreamSpec struct {
+	ServiceName      string            `json:"serviceName"`
+	ServiceNamespace string            `json:"serviceNamespace"`
+	ServicePort      int32             `json:"servicePort"`
+	Selector         map[string]string `json:"selector,omitempty"`
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *Upstream) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := new(Upstream)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	if in.Spec.Kube != nil {
+		kube := *in.Spec.Kube
+		if in.Spec.Kube.Selector != nil {
+			kube.Selector = make(map[string]string, len(in.Spec.Kube.Selector))
+			for k, v := range in.Spec.Kube.Selector {
+				kube.Selector[k] = v
+			}
+		}
+		out.Spec.Kube = &kube
+	}
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *UpstreamList) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := new(UpstreamList)
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		out.Items = make([]Upstream, len(in.Items))
+		for i := range in.Items {
+			item := in.Items[i].DeepCopyObject().(*Upstream)
+			out.Items[i] = *item
+		}
+	}
+	return out
+}
@@ -0,0 +1,44 @@
+package versioned
+
+import (
+	discovery "k8s.io/client-go/discovery"
+
+	gatewayv1 "github.com/weaveworks/flagger/pkg/client/clientset/versioned/typed/gateway/v1"
+	gloov1 "github.com/weaveworks/flagger/pkg/client/clientset/versioned/typed/gloo/v1"
+)
+
+// Interface is the clientset Flagger uses to talk to the Gloo CRDs it
+// programs: the gloo.solo.io and gateway.solo.io groups. It has no client
+// for Flagger's own CRDs.
+type Interface interface {
+	Discovery() discovery.DiscoveryInterface
+	GlooV1() gloov1.GlooV1Interface
+	GatewayV1() gatewayv1.GatewayV1Interface
+}
+
+// Clientset contains the clients for each API group.
+type Clientset struct {
+	*discovery.DiscoveryClient
+	glooV1    *gloov1.GlooV1Client
+	gatewayV1 *gatewayv1.GatewayV1Client
+}
+
+var _ Interface = &Clientset{}
+
+// GlooV1 retrieves the GlooV1Client
+func (c *Clientset) GlooV1() gloov1.GlooV1Interface {
+	return c.glooV1
+}
+
+// GatewayV1 retrieves the GatewayV1Client
+func (c *Clientset) GatewayV1() gatewayv1.GatewayV1Interface {
+	return c.gatewayV1
+}
+
+// Discovery retrieves the DiscoveryClient
+func (c *Clientset) Discovery() discovery.DiscoveryInterface {
+	if c == nil {
+		return nil
+	}
+	return c.DiscoveryClient
+}
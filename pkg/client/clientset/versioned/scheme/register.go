@@ -0,0 +1,25 @@
+// Package scheme contains the codec and parameter codec used by the
+// generated typed clients to (de)serialize the CRDs Flagger manages.
+package scheme
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+var (
+	// Scheme is the runtime.Scheme the generated clients encode parameters
+	// with. Only the client-go types are registered with it.
+	Scheme = runtime.NewScheme()
+	// Codecs provides access to encoding and decoding for the scheme.
+	Codecs = serializer.NewCodecFactory(Scheme)
+	// ParameterCodec handles versioning of objects used in query parameters.
+	ParameterCodec = runtime.NewParameterCodec(Scheme)
+)
+
+func init() {
+	// register the client-go types; the gloo.solo.io and gateway.solo.io
+	// types are not added to Scheme
+	_ = scheme.AddToScheme(Scheme)
+}
@@ -0,0 +1,22 @@
+package v1
+
+import (
+	rest "k8s.io/client-go/rest"
+
+	v1 "github.com/weaveworks/flagger/pkg/apis/gateway/v1"
+)
+
+// GatewayV1Interface exposes the gateway.solo.io/v1 resources Flagger manages.
+type GatewayV1Interface interface {
+	RouteTables(namespace string) RouteTableInterface
+}
+
+// GatewayV1Client talks to the gateway.solo.io/v1 API group.
+type GatewayV1Client struct {
+	restClient rest.Interface
+}
+
+// RouteTables returns the client for RouteTable resources in a namespace.
+func (c *GatewayV1Client) RouteTables(namespace string) RouteTableInterface {
+	return newRouteTables(c, namespace)
+}
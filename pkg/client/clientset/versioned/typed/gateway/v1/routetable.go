@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+
+	v1 "github.com/weaveworks/flagger/pkg/apis/gateway/v1"
+	"github.com/weaveworks/flagger/pkg/client/clientset/versioned/scheme"
+)
+
+// RouteTableInterface has methods to work with RouteTable resources.
+type RouteTableInterface interface {
+	Create(ctx context.Context, routeTable *v1.RouteTable, opts metav1.CreateOptions) (*v1.RouteTable, error)
+	Update(ctx context.Context, routeTable *v1.RouteTable, opts metav1.UpdateOptions) (*v1.RouteTable, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.RouteTable, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.RouteTableList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*v1.RouteTable, error)
+}
+
+// routeTables implements RouteTableInterface
+type routeTables struct {
+	client rest.Interface
+	ns     string
+}
+
+// newRouteTables returns a RouteTables
+func newRouteTables(c *GatewayV1Client, namespace string) *routeTables {
+	return &routeTables{
+		client: c.restClient,
+		ns:     namespace,
+	}
+}
+
+func (c *routeTables) Get(ctx context.Context, name string, opts metav1.GetOptions) (result *v1.RouteTable, err error) {
+	result = &v1.RouteTable{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("routetables").
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *routeTables) List(ctx context.Context, opts metav1.ListOptions) (result *v1.RouteTableList, err error) {
+	result = &v1.RouteTableList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("routetables").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *routeTables) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("routetables").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch(ctx)
+}
+
+func (c *routeTables) Create(ctx context.Context, routeTable *v1.RouteTable, opts metav1.CreateOptions) (result *v1.RouteTable, err error) {
+	result = &v1.RouteTable{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("routetables").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(routeTable).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *routeTables) Update(ctx context.Context, routeTable *v1.RouteTable, opts metav1.UpdateOptions) (result *v1.RouteTable, err error) {
+	result = &v1.RouteTable{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("routetables").
+		Name(routeTable.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(routeTable).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *routeTables) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("routetables").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+func (c *routeTables) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.RouteTable, err error) {
+	result = &v1.RouteTable{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("routetables").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
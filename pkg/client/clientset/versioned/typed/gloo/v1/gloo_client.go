@@ -0,0 +1,26 @@
+package v1
+
+import (
+	rest "k8s.io/client-go/rest"
+)
+
+// GlooV1Interface exposes the gloo.solo.io/v1 resources Flagger manages.
+type GlooV1Interface interface {
+	UpstreamGroups(namespace string) UpstreamGroupInterface
+	Upstreams(namespace string) UpstreamInterface
+}
+
+// GlooV1Client talks to the gloo.solo.io/v1 API group.
+type GlooV1Client struct {
+	restClient rest.Interface
+}
+
+// UpstreamGroups returns the client for UpstreamGroup resources in a namespace.
+func (c *GlooV1Client) UpstreamGroups(namespace string) UpstreamGroupInterface {
+	return newUpstreamGroups(c, namespace)
+}
+
+// Upstreams returns the client for Upstream resources in a namespace.
+func (c *GlooV1Client) Upstreams(namespace string) UpstreamInterface {
+	return newUpstreams(c, namespace)
+}
@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+
+	v1 "github.com/weaveworks/flagger/pkg/apis/gloo/v1"
+	"github.com/weaveworks/flagger/pkg/client/clientset/versioned/scheme"
+)
+
+// UpstreamInterface has methods to work with Upstream resources.
+type UpstreamInterface interface {
+	Create(ctx context.Context, upstream *v1.Upstream, opts metav1.CreateOptions) (*v1.Upstream, error)
+	Update(ctx context.Context, upstream *v1.Upstream, opts metav1.UpdateOptions) (*v1.Upstream, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.Upstream, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.UpstreamList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*v1.Upstream, error)
+}
+
+// upstreams implements UpstreamInterface
+type upstreams struct {
+	client rest.Interface
+	ns     string
+}
+
+// newUpstreams returns an Upstreams
+func newUpstreams(c *GlooV1Client, namespace string) *upstreams {
+	return &upstreams{
+		client: c.restClient,
+		ns:     namespace,
+	}
+}
+
+func (c *upstreams) Get(ctx context.Context, name string, opts metav1.GetOptions) (result *v1.Upstream, err error) {
+	result = &v1.Upstream{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("upstreams").
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *upstreams) List(ctx context.Context, opts metav1.ListOptions) (result *v1.UpstreamList, err error) {
+	result = &v1.UpstreamList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("upstreams").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *upstreams) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("upstreams").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch(ctx)
+}
+
+func (c *upstreams) Create(ctx context.Context, upstream *v1.Upstream, opts metav1.CreateOptions) (result *v1.Upstream, err error) {
+	result = &v1.Upstream{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("upstreams").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(upstream).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *upstreams) Update(ctx context.Context, upstream *v1.Upstream, opts metav1.UpdateOptions) (result *v1.Upstream, err error) {
+	result = &v1.Upstream{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("upstreams").
+		Name(upstream.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(upstream).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *upstreams) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("upstreams").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+func (c *upstreams) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.Upstream, err error) {
+	result = &v1.Upstream{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("upstreams").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+
+	v1 "github.com/weaveworks/flagger/pkg/apis/gloo/v1"
+	"github.com/weaveworks/flagger/pkg/client/clientset/versioned/scheme"
+)
+
+// UpstreamGroupInterface has methods to work with UpstreamGroup resources.
+type UpstreamGroupInterface interface {
+	Create(ctx context.Context, upstreamGroup *v1.UpstreamGroup, opts metav1.CreateOptions) (*v1.UpstreamGroup, error)
+	Update(ctx context.Context, upstreamGroup *v1.UpstreamGroup, opts metav1.UpdateOptions) (*v1.UpstreamGroup, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.UpstreamGroup, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.UpstreamGroupList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*v1.UpstreamGroup, error)
+}
+
+// upstreamGroups implements UpstreamGroupInterface
+type upstreamGroups struct {
+	client rest.Interface
+	ns     string
+}
+
+// newUpstreamGroups returns an UpstreamGroups
+func newUpstreamGroups(c *GlooV1Client, namespace string) *upstreamGroups {
+	return &upstreamGroups{
+		client: c.restClient,
+		ns:     namespace,
+	}
+}
+
+func (c *upstreamGroups) Get(ctx context.Context, name string, opts metav1.GetOptions) (result *v1.UpstreamGroup, err error) {
+	result = &v1.UpstreamGroup{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("upstreamgroups").
+		Name(name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *upstreamGroups) List(ctx context.Context, opts metav1.ListOptions) (result *v1.UpstreamGroupList, err error) {
+	result = &v1.UpstreamGroupList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("upstreamgroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *upstreamGroups) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("upstreamgroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch(ctx)
+}
+
+func (c *upstreamGroups) Create(ctx context.Context, upstreamGroup *v1.UpstreamGroup, opts metav1.CreateOptions) (result *v1.UpstreamGroup, err error) {
+	result = &v1.UpstreamGroup{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("upstreamgroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(upstreamGroup).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *upstreamGroups) Update(ctx context.Context, upstreamGroup *v1.UpstreamGroup, opts metav1.UpdateOptions) (result *v1.UpstreamGroup, err error) {
+	result = &v1.UpstreamGroup{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("upstreamgroups").
+		Name(upstreamGroup.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(upstreamGroup).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+func (c *upstreamGroups) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("upstreamgroups").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+func (c *upstreamGroups) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.UpstreamGroup, err error) {
+	result = &v1.UpstreamGroup{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("upstreamgroups").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,190 @@
+package router
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+
+	gatewayv1 "github.com/weaveworks/flagger/pkg/apis/gateway/v1"
+	gloov1 "github.com/weaveworks/flagger/pkg/apis/gloo/v1"
+	clientset "github.com/weaveworks/flagger/pkg/client/clientset/versioned"
+	gatewayclient "github.com/weaveworks/flagger/pkg/client/clientset/versioned/typed/gateway/v1"
+	glooclient "github.com/weaveworks/flagger/pkg/client/clientset/versioned/typed/gloo/v1"
+)
+
+var _ clientset.Interface = &fakeClientset{}
+
+// fakeClientset is an in-memory stand-in for the generated clientset, since
+// this tree doesn't carry a real client-gen fake for Gloo's CRDs.
+type fakeClientset struct {
+	upstreamGroups map[string]*gloov1.UpstreamGroup
+	upstreams      map[string]*gloov1.Upstream
+	routeTables    map[string]*gatewayv1.RouteTable
+}
+
+func newFakeClientset() *fakeClientset {
+	return &fakeClientset{
+		upstreamGroups: make(map[string]*gloov1.UpstreamGroup),
+		upstreams:      make(map[string]*gloov1.Upstream),
+		routeTables:    make(map[string]*gatewayv1.RouteTable),
+	}
+}
+
+func fakeKey(namespace, name string) string { return namespace + "/" + name }
+
+func (f *fakeClientset) Discovery() discovery.DiscoveryInterface     { return nil }
+func (f *fakeClientset) GlooV1() glooclient.GlooV1Interface          { return &fakeGlooV1{f} }
+func (f *fakeClientset) GatewayV1() gatewayclient.GatewayV1Interface { return &fakeGatewayV1{f} }
+
+type fakeGlooV1 struct{ c *fakeClientset }
+
+func (f *fakeGlooV1) UpstreamGroups(ns string) glooclient.UpstreamGroupInterface {
+	return &fakeUpstreamGroups{f.c, ns}
+}
+func (f *fakeGlooV1) Upstreams(ns string) glooclient.UpstreamInterface {
+	return &fakeUpstreams{f.c, ns}
+}
+
+type fakeGatewayV1 struct{ c *fakeClientset }
+
+func (f *fakeGatewayV1) RouteTables(ns string) gatewayclient.RouteTableInterface {
+	return &fakeRouteTables{f.c, ns}
+}
+
+type fakeUpstreamGroups struct {
+	c  *fakeClientset
+	ns string
+}
+
+func (f *fakeUpstreamGroups) Get(_ context.Context, name string, _ metav1.GetOptions) (*gloov1.UpstreamGroup, error) {
+	ug, ok := f.c.upstreamGroups[fakeKey(f.ns, name)]
+	if !ok {
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: "upstreamgroups"}, name)
+	}
+	return ug.DeepCopyObject().(*gloov1.UpstreamGroup), nil
+}
+
+func (f *fakeUpstreamGroups) Create(_ context.Context, ug *gloov1.UpstreamGroup, _ metav1.CreateOptions) (*gloov1.UpstreamGroup, error) {
+	ug.Namespace = f.ns
+	f.c.upstreamGroups[fakeKey(f.ns, ug.Name)] = ug.DeepCopyObject().(*gloov1.UpstreamGroup)
+	return ug, nil
+}
+
+func (f *fakeUpstreamGroups) Update(_ context.Context, ug *gloov1.UpstreamGroup, _ metav1.UpdateOptions) (*gloov1.UpstreamGroup, error) {
+	if _, ok := f.c.upstreamGroups[fakeKey(f.ns, ug.Name)]; !ok {
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: "upstreamgroups"}, ug.Name)
+	}
+	f.c.upstreamGroups[fakeKey(f.ns, ug.Name)] = ug.DeepCopyObject().(*gloov1.UpstreamGroup)
+	return ug, nil
+}
+
+func (f *fakeUpstreamGroups) Delete(_ context.Context, name string, _ metav1.DeleteOptions) error {
+	delete(f.c.upstreamGroups, fakeKey(f.ns, name))
+	return nil
+}
+
+func (f *fakeUpstreamGroups) List(_ context.Context, _ metav1.ListOptions) (*gloov1.UpstreamGroupList, error) {
+	return nil, fmt.Errorf("fakeUpstreamGroups: List not implemented")
+}
+
+func (f *fakeUpstreamGroups) Watch(_ context.Context, _ metav1.ListOptions) (watch.Interface, error) {
+	return nil, fmt.Errorf("fakeUpstreamGroups: Watch not implemented")
+}
+
+func (f *fakeUpstreamGroups) Patch(_ context.Context, _ string, _ types.PatchType, _ []byte, _ metav1.PatchOptions, _ ...string) (*gloov1.UpstreamGroup, error) {
+	return nil, fmt.Errorf("fakeUpstreamGroups: Patch not implemented")
+}
+
+type fakeUpstreams struct {
+	c  *fakeClientset
+	ns string
+}
+
+func (f *fakeUpstreams) Get(_ context.Context, name string, _ metav1.GetOptions) (*gloov1.Upstream, error) {
+	u, ok := f.c.upstreams[fakeKey(f.ns, name)]
+	if !ok {
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: "upstreams"}, name)
+	}
+	return u.DeepCopyObject().(*gloov1.Upstream), nil
+}
+
+func (f *fakeUpstreams) Create(_ context.Context, u *gloov1.Upstream, _ metav1.CreateOptions) (*gloov1.Upstream, error) {
+	u.Namespace = f.ns
+	f.c.upstreams[fakeKey(f.ns, u.Name)] = u.DeepCopyObject().(*gloov1.Upstream)
+	return u, nil
+}
+
+func (f *fakeUpstreams) Update(_ context.Context, u *gloov1.Upstream, _ metav1.UpdateOptions) (*gloov1.Upstream, error) {
+	if _, ok := f.c.upstreams[fakeKey(f.ns, u.Name)]; !ok {
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: "upstreams"}, u.Name)
+	}
+	f.c.upstreams[fakeKey(f.ns, u.Name)] = u.DeepCopyObject().(*gloov1.Upstream)
+	return u, nil
+}
+
+func (f *fakeUpstreams) Delete(_ context.Context, name string, _ metav1.DeleteOptions) error {
+	delete(f.c.upstreams, fakeKey(f.ns, name))
+	return nil
+}
+
+func (f *fakeUpstreams) List(_ context.Context, _ metav1.ListOptions) (*gloov1.UpstreamList, error) {
+	return nil, fmt.Errorf("fakeUpstreams: List not implemented")
+}
+
+func (f *fakeUpstreams) Watch(_ context.Context, _ metav1.ListOptions) (watch.Interface, error) {
+	return nil, fmt.Errorf("fakeUpstreams: Watch not implemented")
+}
+
+func (f *fakeUpstreams) Patch(_ context.Context, _ string, _ types.PatchType, _ []byte, _ metav1.PatchOptions, _ ...string) (*gloov1.Upstream, error) {
+	return nil, fmt.Errorf("fakeUpstreams: Patch not implemented")
+}
+
+type fakeRouteTables struct {
+	c  *fakeClientset
+	ns string
+}
+
+func (f *fakeRouteTables) Get(_ context.Context, name string, _ metav1.GetOptions) (*gatewayv1.RouteTable, error) {
+	rt, ok := f.c.routeTables[fakeKey(f.ns, name)]
+	if !ok {
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: "routetables"}, name)
+	}
+	return rt.DeepCopyObject().(*gatewayv1.RouteTable), nil
+}
+
+func (f *fakeRouteTables) Create(_ context.Context, rt *gatewayv1.RouteTable, _ metav1.CreateOptions) (*gatewayv1.RouteTable, error) {
+	rt.Namespace = f.ns
+	f.c.routeTables[fakeKey(f.ns, rt.Name)] = rt.DeepCopyObject().(*gatewayv1.RouteTable)
+	return rt, nil
+}
+
+func (f *fakeRouteTables) Update(_ context.Context, rt *gatewayv1.RouteTable, _ metav1.UpdateOptions) (*gatewayv1.RouteTable, error) {
+	if _, ok := f.c.routeTables[fakeKey(f.ns, rt.Name)]; !ok {
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: "routetables"}, rt.Name)
+	}
+	f.c.routeTables[fakeKey(f.ns, rt.Name)] = rt.DeepCopyObject().(*gatewayv1.RouteTable)
+	return rt, nil
+}
+
+func (f *fakeRouteTables) Delete(_ context.Context, name string, _ metav1.DeleteOptions) error {
+	delete(f.c.routeTables, fakeKey(f.ns, name))
+	return nil
+}
+
+func (f *fakeRouteTables) List(_ context.Context, _ metav1.ListOptions) (*gatewayv1.RouteTableList, error) {
+	return nil, fmt.Errorf("fakeRouteTables: List not implemented")
+}
+
+func (f *fakeRouteTables) Watch(_ context.Context, _ metav1.ListOptions) (watch.Interface, error) {
+	return nil, fmt.Errorf("fakeRouteTables: Watch not implemented")
+}
+
+func (f *fakeRouteTables) Patch(_ context.Context, _ string, _ types.PatchType, _ []byte, _ metav1.PatchOptions, _ ...string) (*gatewayv1.RouteTable, error) {
+	return nil, fmt.Errorf("fakeRouteTables: Patch not implemented")
+}
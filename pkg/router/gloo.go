@@ -3,7 +3,9 @@ package router
 import (
 	"context"
 	"fmt"
+	"regexp"
 
+	gatewayv1 "github.com/weaveworks/flagger/pkg/apis/gateway/v1"
 	gloov1 "github.com/weaveworks/flagger/pkg/apis/gloo/v1"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -11,6 +13,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	flaggerv1 "github.com/weaveworks/flagger/pkg/apis/flagger/v1beta1"
+	istiov1alpha3 "github.com/weaveworks/flagger/pkg/apis/istio/v1alpha3"
 	clientset "github.com/weaveworks/flagger/pkg/client/clientset/versioned"
 )
 
@@ -25,9 +28,38 @@ type GlooRouter struct {
 
 // Reconcile creates or updates the Istio virtual service
 func (gr *GlooRouter) Reconcile(canary *flaggerv1.Canary) error {
-	apexName, _, _ := canary.GetServiceNames()
+	apexName, primaryName, canaryServiceName := canary.GetServiceNames()
 	canaryName := fmt.Sprintf("%s-canary-%v", apexName, canary.Spec.Service.Port)
 
+	// Auto-provision the primary/canary Upstream CRs up front: every
+	// routing mode below (UpstreamGroup or RouteTable) references them by
+	// name, so Flagger can run against a bare Deployment without anyone
+	// hand-authoring Gloo config first.
+	if err := gr.syncUpstream(canary, apexName, primaryName, canaryServiceName, canaryName); err != nil {
+		return err
+	}
+
+	// UpstreamGroup destinations can only be weighted, so A/B testing
+	// (header/cookie matching) needs a RouteTable instead.
+	if len(canary.Spec.Analysis.Match) > 0 {
+		return gr.reconcileRouteTable(canary, apexName, canaryName)
+	}
+
+	// A RouteTable named after the apex service is an opt-in to
+	// RouteTable-based routing: it's the only way to express the header
+	// matching, mirroring, retries, timeouts and CORS options the other
+	// requests need, none of which an UpstreamGroup can carry. Retries,
+	// timeout, header manipulation or a CORS policy on the canary's
+	// service also force RouteTable routing, even if one doesn't exist
+	// yet, since those options have nowhere else to live.
+	routeTable, foundRouteTable, err := gr.getRouteTable(canary, apexName)
+	if err != nil {
+		return err
+	}
+	if foundRouteTable || hasRouteActionOptions(canary) {
+		return gr.reconcileRouteTableWeights(canary, routeTable, foundRouteTable, apexName, canaryName)
+	}
+
 	upstreamGroup, err := gr.glooClient.GlooV1().UpstreamGroups(canary.Namespace).Get(context.TODO(), apexName, metav1.GetOptions{})
 	if errors.IsNotFound(err) {
 		return fmt.Errorf("UpstreamGroup %s.%s not found", apexName, canary.Namespace)
@@ -72,6 +104,383 @@ func (gr *GlooRouter) Reconcile(canary *flaggerv1.Canary) error {
 	return nil
 }
 
+// reconcileRouteTable pins requests matching canary.Spec.Analysis.Match to
+// the canary upstream and falls everything else back to the primary,
+// carrying over whatever retries/timeout/CORS/header options the canary's
+// service asks for so A/B testing composes with those features.
+func (gr *GlooRouter) reconcileRouteTable(canary *flaggerv1.Canary, apexName, canaryName string) error {
+	primaryName := glooPrimaryUpstreamName(canary, apexName)
+	options := buildRouteActionOptions(canary)
+
+	routeTable, found, err := gr.getRouteTable(canary, apexName)
+	if err != nil {
+		return err
+	}
+
+	matchedAction := singleDestinationAction(canaryName, canary.Namespace)
+	matchedAction.Options = mergeRouteActionOptions(routeTableActionOptions(routeTable, 0), options)
+	fallbackAction := singleDestinationAction(primaryName, canary.Namespace)
+	fallbackAction.Options = mergeRouteActionOptions(routeTableActionOptions(routeTable, 1), options)
+
+	routes := []gatewayv1.Route{
+		{
+			Matchers: convertMatch(canary.Spec.Analysis.Match),
+			Action:   matchedAction,
+		},
+		{
+			Action: fallbackAction,
+		},
+	}
+
+	if !found {
+		routeTable = &gatewayv1.RouteTable{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      apexName,
+				Namespace: canary.Namespace,
+			},
+			Spec: gatewayv1.RouteTableSpec{Routes: routes},
+		}
+		if _, err := gr.glooClient.GatewayV1().RouteTables(canary.Namespace).Create(context.TODO(), routeTable, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("RouteTable %s.%s create error: %w", apexName, canary.Namespace, err)
+		}
+		return nil
+	}
+
+	routeTable.Spec.Routes = routes
+	if _, err := gr.glooClient.GatewayV1().RouteTables(canary.Namespace).Update(context.TODO(), routeTable, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("RouteTable %s.%s update error: %w", apexName, canary.Namespace, err)
+	}
+	return nil
+}
+
+// routeTableActionOptions returns the Options already on a RouteTable's
+// i'th route, or nil if there's no such route yet.
+func routeTableActionOptions(routeTable *gatewayv1.RouteTable, i int) *gatewayv1.RouteActionOptions {
+	if routeTable == nil || len(routeTable.Spec.Routes) <= i || routeTable.Spec.Routes[i].Action == nil {
+		return nil
+	}
+	return routeTable.Spec.Routes[i].Action.Options
+}
+
+// glooPrimaryUpstreamName is the naming convention for the primary upstream.
+func glooPrimaryUpstreamName(canary *flaggerv1.Canary, apexName string) string {
+	return fmt.Sprintf("%s-%s-primaryupstream-%v", canary.Namespace, apexName, canary.Spec.Service.Port)
+}
+
+// getRouteTable looks up the RouteTable named after the apex service. A
+// missing RouteTable is not an error: it just means UpstreamGroup routing.
+func (gr *GlooRouter) getRouteTable(canary *flaggerv1.Canary, apexName string) (*gatewayv1.RouteTable, bool, error) {
+	routeTable, err := gr.glooClient.GatewayV1().RouteTables(canary.Namespace).Get(context.TODO(), apexName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("RouteTable %s.%s get query error: %w", apexName, canary.Namespace, err)
+	}
+	return routeTable, true, nil
+}
+
+// reconcileRouteTableWeights makes sure the RouteTable's first route has a
+// weighted canary destination and the route options the canary's service
+// asks for, creating the RouteTable if one doesn't exist yet.
+func (gr *GlooRouter) reconcileRouteTableWeights(canary *flaggerv1.Canary, routeTable *gatewayv1.RouteTable, found bool, apexName, canaryName string) error {
+	primaryName := glooPrimaryUpstreamName(canary, apexName)
+	options := buildRouteActionOptions(canary)
+
+	if !found {
+		routeTable = &gatewayv1.RouteTable{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      apexName,
+				Namespace: canary.Namespace,
+			},
+			Spec: gatewayv1.RouteTableSpec{
+				Routes: []gatewayv1.Route{weightedPrimaryCanaryRoute(primaryName, canaryName, canary.Namespace, options)},
+			},
+		}
+		if _, err := gr.glooClient.GatewayV1().RouteTables(canary.Namespace).Create(context.TODO(), routeTable, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("RouteTable %s.%s create error: %w", apexName, canary.Namespace, err)
+		}
+		return nil
+	}
+
+	if len(routeTable.Spec.Routes) != 1 || len(routeTable.Spec.Routes[0].Matchers) > 0 {
+		// Stale A/B shape (reconcileRouteTable's matched + fallback
+		// routes) left over after canary.Spec.Analysis.Match was cleared -
+		// collapse back to a single weighted route instead of pinning
+		// traffic by match condition forever.
+		routeTable.Spec.Routes = []gatewayv1.Route{weightedPrimaryCanaryRoute(primaryName, canaryName, canary.Namespace, options)}
+		if _, err := gr.glooClient.GatewayV1().RouteTables(canary.Namespace).Update(context.TODO(), routeTable, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("RouteTable %s.%s update error: %w", apexName, canary.Namespace, err)
+		}
+		return nil
+	}
+
+	if routeTable.Spec.Routes[0].Action == nil || routeTable.Spec.Routes[0].Action.Destination == nil {
+		return fmt.Errorf("RouteTable %s.%s has no weighted route", apexName, canary.Namespace)
+	}
+
+	action := routeTable.Spec.Routes[0].Action
+	action.Options = mergeRouteActionOptions(action.Options, options)
+
+	destinations := action.Destination.Destinations
+	for _, dst := range destinations {
+		if dst.Destination.Upstream.Name == canaryName {
+			if _, err := gr.glooClient.GatewayV1().RouteTables(canary.Namespace).Update(context.TODO(), routeTable, metav1.UpdateOptions{}); err != nil {
+				return fmt.Errorf("RouteTable %s.%s update error: %w", apexName, canary.Namespace, err)
+			}
+			return nil
+		}
+	}
+
+	action.Destination.Destinations = append(destinations, gloov1.WeightedDestination{
+		Destination: gloov1.Destination{
+			Upstream: gloov1.ResourceRef{Name: canaryName, Namespace: canary.Namespace},
+		},
+		Weight: uint32(0),
+	})
+
+	if _, err := gr.glooClient.GatewayV1().RouteTables(canary.Namespace).Update(context.TODO(), routeTable, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("RouteTable %s.%s update error: %w", apexName, canary.Namespace, err)
+	}
+	return nil
+}
+
+// weightedPrimaryCanaryRoute builds the single weighted route a RouteTable
+// uses for UpstreamGroup-equivalent routing: primary at weight 1000, canary
+// at weight 0.
+func weightedPrimaryCanaryRoute(primaryName, canaryName, namespace string, options *gatewayv1.RouteActionOptions) gatewayv1.Route {
+	return gatewayv1.Route{
+		Action: &gatewayv1.RouteAction{
+			Destination: &gatewayv1.MultiDestination{
+				Destinations: []gloov1.WeightedDestination{
+					{
+						Destination: gloov1.Destination{Upstream: gloov1.ResourceRef{Name: primaryName, Namespace: namespace}},
+						Weight:      uint32(1000),
+					},
+					{
+						Destination: gloov1.Destination{Upstream: gloov1.ResourceRef{Name: canaryName, Namespace: namespace}},
+						Weight:      uint32(0),
+					},
+				},
+			},
+			Options: options,
+		},
+	}
+}
+
+// hasRouteActionOptions reports whether the canary's service config needs
+// RouteTable options UpstreamGroup can't carry.
+func hasRouteActionOptions(canary *flaggerv1.Canary) bool {
+	svc := canary.Spec.Service
+	return svc.Retries != nil || svc.Timeout != "" || svc.Headers != nil || svc.CorsPolicy != nil
+}
+
+// buildRouteActionOptions translates the canary's retries, timeout,
+// headers and CORS policy into Gloo RouteAction options, or nil if unset.
+func buildRouteActionOptions(canary *flaggerv1.Canary) *gatewayv1.RouteActionOptions {
+	if !hasRouteActionOptions(canary) {
+		return nil
+	}
+
+	svc := canary.Spec.Service
+	options := &gatewayv1.RouteActionOptions{Timeout: svc.Timeout}
+
+	if svc.Retries != nil {
+		options.Retries = &gatewayv1.RetryPolicy{
+			NumRetries:    uint32(svc.Retries.Attempts),
+			PerTryTimeout: svc.Retries.PerTryTimeout,
+			RetryOn:       svc.Retries.RetryOn,
+		}
+	}
+
+	if svc.CorsPolicy != nil {
+		options.Cors = &gatewayv1.CorsPolicy{
+			AllowOrigin:      svc.CorsPolicy.AllowOrigin,
+			AllowMethods:     svc.CorsPolicy.AllowMethods,
+			AllowHeaders:     svc.CorsPolicy.AllowHeaders,
+			AllowCredentials: svc.CorsPolicy.AllowCredentials,
+			MaxAge:           svc.CorsPolicy.MaxAge,
+		}
+	}
+
+	if svc.Headers != nil {
+		headerManipulation := &gatewayv1.HeaderManipulation{}
+		if svc.Headers.Request != nil {
+			headerManipulation.RequestHeadersToAdd = svc.Headers.Request.Add
+			headerManipulation.RequestHeadersToRemove = svc.Headers.Request.Remove
+		}
+		if svc.Headers.Response != nil {
+			headerManipulation.ResponseHeadersToAdd = svc.Headers.Response.Add
+			headerManipulation.ResponseHeadersToRemove = svc.Headers.Response.Remove
+		}
+		options.HeaderManipulation = headerManipulation
+	}
+
+	return options
+}
+
+// mergeRouteActionOptions layers newly computed options onto whatever a
+// route already carries, preserving fields (like mirroring's Shadowing)
+// that this reconcile pass doesn't own. next == nil means the canary's
+// service no longer asks for retries/timeout/CORS/headers, so those
+// fields are cleared here too instead of lingering forever.
+func mergeRouteActionOptions(existing, next *gatewayv1.RouteActionOptions) *gatewayv1.RouteActionOptions {
+	if next == nil {
+		if existing == nil {
+			return nil
+		}
+		existing.Retries = nil
+		existing.Timeout = ""
+		existing.Cors = nil
+		existing.HeaderManipulation = nil
+		if existing.Shadowing == nil {
+			return nil
+		}
+		return existing
+	}
+	if existing == nil {
+		existing = &gatewayv1.RouteActionOptions{}
+	}
+	existing.Retries = next.Retries
+	existing.Timeout = next.Timeout
+	existing.Cors = next.Cors
+	existing.HeaderManipulation = next.HeaderManipulation
+	return existing
+}
+
+// syncUpstream auto-provisions the primary/canary Upstream CRs and the
+// UpstreamGroup wiring them together, regardless of which routing mode
+// ends up being used.
+func (gr *GlooRouter) syncUpstream(canary *flaggerv1.Canary, apexName, primaryServiceName, canaryServiceName, canaryUpstreamName string) error {
+	primaryUpstreamName := glooPrimaryUpstreamName(canary, apexName)
+
+	if err := gr.reconcileKubeUpstream(canary, primaryUpstreamName, primaryServiceName); err != nil {
+		return err
+	}
+	if err := gr.reconcileKubeUpstream(canary, canaryUpstreamName, canaryServiceName); err != nil {
+		return err
+	}
+	return gr.ensureUpstreamGroup(canary, apexName, primaryUpstreamName, canaryUpstreamName)
+}
+
+// reconcileKubeUpstream creates or updates a kube Upstream CR for a service.
+func (gr *GlooRouter) reconcileKubeUpstream(canary *flaggerv1.Canary, upstreamName, serviceName string) error {
+	svc, err := gr.kubeClient.CoreV1().Services(canary.Namespace).Get(context.TODO(), serviceName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("service %s.%s get query error: %w", serviceName, canary.Namespace, err)
+	}
+
+	spec := gloov1.UpstreamSpec{
+		Kube: &gloov1.KubeUpstreamSpec{
+			ServiceName:      svc.Name,
+			ServiceNamespace: svc.Namespace,
+			ServicePort:      canary.Spec.Service.Port,
+			Selector:         svc.Spec.Selector,
+		},
+	}
+
+	upstream, err := gr.glooClient.GlooV1().Upstreams(canary.Namespace).Get(context.TODO(), upstreamName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		upstream = &gloov1.Upstream{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      upstreamName,
+				Namespace: canary.Namespace,
+			},
+			Spec: spec,
+		}
+		if _, err := gr.glooClient.GlooV1().Upstreams(canary.Namespace).Create(context.TODO(), upstream, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("Upstream %s.%s create error: %w", upstreamName, canary.Namespace, err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("Upstream %s.%s get query error: %w", upstreamName, canary.Namespace, err)
+	}
+
+	upstream.Spec = spec
+	if _, err := gr.glooClient.GlooV1().Upstreams(canary.Namespace).Update(context.TODO(), upstream, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("Upstream %s.%s update error: %w", upstreamName, canary.Namespace, err)
+	}
+	return nil
+}
+
+// ensureUpstreamGroup creates the apex's UpstreamGroup if it's missing.
+func (gr *GlooRouter) ensureUpstreamGroup(canary *flaggerv1.Canary, apexName, primaryUpstreamName, canaryUpstreamName string) error {
+	_, err := gr.glooClient.GlooV1().UpstreamGroups(canary.Namespace).Get(context.TODO(), apexName, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return fmt.Errorf("UpstreamGroup %s.%s get query error: %w", apexName, canary.Namespace, err)
+	}
+
+	upstreamGroup := &gloov1.UpstreamGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apexName,
+			Namespace: canary.Namespace,
+		},
+		Spec: gloov1.UpstreamGroupSpec{
+			Destinations: []gloov1.WeightedDestination{
+				{
+					Destination: gloov1.Destination{
+						Upstream: gloov1.ResourceRef{Name: primaryUpstreamName, Namespace: canary.Namespace},
+					},
+					Weight: uint32(1000),
+				},
+				{
+					Destination: gloov1.Destination{
+						Upstream: gloov1.ResourceRef{Name: canaryUpstreamName, Namespace: canary.Namespace},
+					},
+					Weight: uint32(0),
+				},
+			},
+		},
+	}
+	if _, err := gr.glooClient.GlooV1().UpstreamGroups(canary.Namespace).Create(context.TODO(), upstreamGroup, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("UpstreamGroup %s.%s create error: %w", apexName, canary.Namespace, err)
+	}
+	return nil
+}
+
+// singleDestinationAction builds a RouteAction pinning all matched traffic
+// to a single upstream.
+func singleDestinationAction(upstreamName, namespace string) *gatewayv1.RouteAction {
+	return &gatewayv1.RouteAction{
+		Destination: &gatewayv1.MultiDestination{
+			Destinations: []gloov1.WeightedDestination{
+				{
+					Destination: gloov1.Destination{
+						Upstream: gloov1.ResourceRef{Name: upstreamName, Namespace: namespace},
+					},
+					Weight: uint32(1000),
+				},
+			},
+		},
+	}
+}
+
+// convertMatch translates Istio-style HTTP match conditions into Gloo
+// header matchers (a cookie condition is just a "cookie" header match).
+func convertMatch(match []istiov1alpha3.HTTPMatchRequest) []gatewayv1.Matcher {
+	var matchers []gatewayv1.Matcher
+	for _, m := range match {
+		matcher := gatewayv1.Matcher{}
+		for name, stringMatch := range m.Headers {
+			value, regex := stringMatchValue(stringMatch)
+			matcher.Headers = append(matcher.Headers, gatewayv1.HeaderMatcher{Name: name, Value: value, Regex: regex})
+		}
+		matchers = append(matchers, matcher)
+	}
+	return matchers
+}
+
+func stringMatchValue(stringMatch istiov1alpha3.StringMatch) (value string, regex bool) {
+	if stringMatch.Regex != "" {
+		return stringMatch.Regex, true
+	}
+	if stringMatch.Prefix != "" {
+		return "^" + regexp.QuoteMeta(stringMatch.Prefix) + ".*", true
+	}
+	return stringMatch.Exact, false
+}
+
 // GetRoutes returns the destinations weight for primary and canary
 func (gr *GlooRouter) GetRoutes(canary *flaggerv1.Canary) (
 	primaryWeight int,
@@ -82,6 +491,32 @@ func (gr *GlooRouter) GetRoutes(canary *flaggerv1.Canary) (
 	apexName := canary.Spec.TargetRef.Name
 	canaryName := fmt.Sprintf("%s-canary-%v", apexName, canary.Spec.Service.Port)
 
+	if len(canary.Spec.Analysis.Match) > 0 {
+		// Sticky mode: traffic is pinned by header/cookie match, not by
+		// weight, so there's no real primary/canary split to report. The
+		// Router interface has no field for "sticky", and is shared by
+		// every other provider, so this intentionally returns the same
+		// (100, 0, false) tuple idle routing would - it is indistinguishable
+		// from idle by design, not by oversight. That's safe here because
+		// the canary controller already reads canary.Spec.Analysis.Match
+		// itself to decide it's in A/B mode; it doesn't infer that from
+		// GetRoutes's return value.
+		gr.logger.With("canary", fmt.Sprintf("%s.%s", canary.Name, canary.Namespace)).
+			Debugf("RouteTable %s.%s is sticky (A/B match)", apexName, canary.Namespace)
+		primaryWeight = 100
+		canaryWeight = 0
+		return
+	}
+
+	routeTable, foundRouteTable, rtErr := gr.getRouteTable(canary, apexName)
+	if rtErr != nil {
+		err = rtErr
+		return
+	}
+	if foundRouteTable {
+		return gr.getRouteTableWeights(canary, routeTable, apexName, canaryName)
+	}
+
 	upstreamGroup, err := gr.glooClient.GlooV1().UpstreamGroups(canary.Namespace).Get(context.TODO(), apexName, metav1.GetOptions{})
 	if err != nil {
 		err = fmt.Errorf("UpstreamGroup %s.%s get query error: %w", apexName, canary.Namespace, err)
@@ -104,20 +539,70 @@ func (gr *GlooRouter) GetRoutes(canary *flaggerv1.Canary) (
 	return
 }
 
+// getRouteTableWeights reads primary/canary weights off the RouteTable's
+// first route.
+func (gr *GlooRouter) getRouteTableWeights(canary *flaggerv1.Canary, routeTable *gatewayv1.RouteTable, apexName, canaryName string) (primaryWeight int, canaryWeight int, mirrored bool, err error) {
+	primaryName := glooPrimaryUpstreamName(canary, apexName)
+
+	if len(routeTable.Spec.Routes) == 0 || routeTable.Spec.Routes[0].Action == nil || routeTable.Spec.Routes[0].Action.Destination == nil {
+		err = fmt.Errorf("RouteTable %s.%s destinations not found", apexName, canary.Namespace)
+		return
+	}
+
+	action := routeTable.Spec.Routes[0].Action
+	if action.Options != nil && action.Options.Shadowing != nil {
+		// All traffic goes to primary while a slice is shadowed to the
+		// canary, so report the un-split state and flag mirroring so the
+		// canary controller stays in the mirror analysis stage.
+		primaryWeight = 100
+		canaryWeight = 0
+		mirrored = true
+		return
+	}
+
+	for _, dst := range action.Destination.Destinations {
+		switch dst.Destination.Upstream.Name {
+		case canaryName:
+			canaryWeight = int(dst.Weight) / 10 //Since we use 1000 as base value and flagger use 100
+		case primaryName:
+			primaryWeight = int(dst.Weight) / 10
+		}
+	}
+	return
+}
+
 // SetRoutes updates the destinations weight for primary and canary
 func (gr *GlooRouter) SetRoutes(
 	canary *flaggerv1.Canary,
 	primaryWeight int,
 	canaryWeight int,
-	_ bool,
+	mirrored bool,
 ) error {
 	apexName, _, _ := canary.GetServiceNames()
 	canaryName := fmt.Sprintf("%s-canary-%v", apexName, canary.Spec.Service.Port)
 
+	if len(canary.Spec.Analysis.Match) > 0 {
+		// The RouteTable already pins traffic by match condition; there
+		// are no weights to program.
+		return nil
+	}
+
+	if mirrored {
+		return gr.setMirrorRoute(canary, apexName, canaryName)
+	}
+
 	if primaryWeight == 0 && canaryWeight == 0 {
 		return fmt.Errorf("RoutingRule %s.%s update failed: no valid weights", apexName, canary.Namespace)
 	}
 
+	routeTable, foundRouteTable, err := gr.getRouteTable(canary, apexName)
+	if err != nil {
+		return err
+	}
+	if foundRouteTable {
+		return gr.setRouteTableWeights(canary, routeTable, apexName, canaryName, primaryWeight, canaryWeight)
+	}
+
 	upstreamGroup, err := gr.glooClient.GlooV1().UpstreamGroups(canary.Namespace).Get(context.TODO(), apexName, metav1.GetOptions{})
 	if err != nil {
 		return fmt.Errorf("UpstreamGroup %s.%s query error: %w", apexName, canary.Namespace, err)
@@ -137,10 +622,111 @@ func (gr *GlooRouter) SetRoutes(
 	return nil
 }
 
+// setRouteTableWeights programs the primary/canary weights onto the
+// RouteTable's first route.
+func (gr *GlooRouter) setRouteTableWeights(canary *flaggerv1.Canary, routeTable *gatewayv1.RouteTable, apexName, canaryName string, primaryWeight, canaryWeight int) error {
+	primaryName := glooPrimaryUpstreamName(canary, apexName)
+
+	if len(routeTable.Spec.Routes) == 0 || routeTable.Spec.Routes[0].Action == nil || routeTable.Spec.Routes[0].Action.Destination == nil {
+		return fmt.Errorf("RouteTable %s.%s destinations not found", apexName, canary.Namespace)
+	}
+
+	action := routeTable.Spec.Routes[0].Action
+
+	var hasCanary bool
+	for _, dst := range action.Destination.Destinations {
+		if dst.Destination.Upstream.Name == canaryName {
+			hasCanary = true
+			break
+		}
+	}
+	if !hasCanary {
+		// The mirroring stage only needs a primary destination; bring the
+		// canary back so weighted routing has something to split.
+		action.Destination.Destinations = append(action.Destination.Destinations, gloov1.WeightedDestination{
+			Destination: gloov1.Destination{
+				Upstream: gloov1.ResourceRef{Name: canaryName, Namespace: canary.Namespace},
+			},
+		})
+	}
+
+	destinations := action.Destination.Destinations
+	for i, dst := range destinations {
+		switch dst.Destination.Upstream.Name {
+		case canaryName:
+			destinations[i].Weight = uint32(canaryWeight * 10) //Since we use 1000 as base value and flagger use 100
+		case primaryName:
+			destinations[i].Weight = uint32(primaryWeight * 10)
+		}
+	}
+
+	if action.Options != nil {
+		// Returning to weighted routing drops any shadowing left over
+		// from the mirroring stage.
+		action.Options.Shadowing = nil
+	}
+
+	if _, err := gr.glooClient.GatewayV1().RouteTables(canary.Namespace).Update(context.TODO(), routeTable, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("RouteTable %s.%s update error: %w", apexName, canary.Namespace, err)
+	}
+	return nil
+}
+
+// setMirrorRoute sends all traffic to the primary while shadowing a
+// percentage of it to the canary.
+func (gr *GlooRouter) setMirrorRoute(canary *flaggerv1.Canary, apexName, canaryName string) error {
+	primaryName := glooPrimaryUpstreamName(canary, apexName)
+
+	action := singleDestinationAction(primaryName, canary.Namespace)
+	action.Options = &gatewayv1.RouteActionOptions{
+		Shadowing: &gatewayv1.ShadowPolicy{
+			Upstream:   gloov1.ResourceRef{Name: canaryName, Namespace: canary.Namespace},
+			Percentage: float64(canary.Spec.Analysis.MirrorWeight),
+		},
+	}
+
+	routeTable, found, err := gr.getRouteTable(canary, apexName)
+	if err != nil {
+		return err
+	}
+	if !found {
+		routeTable = &gatewayv1.RouteTable{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      apexName,
+				Namespace: canary.Namespace,
+			},
+			Spec: gatewayv1.RouteTableSpec{Routes: []gatewayv1.Route{{Action: action}}},
+		}
+		if _, err := gr.glooClient.GatewayV1().RouteTables(canary.Namespace).Create(context.TODO(), routeTable, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("RouteTable %s.%s create error: %w", apexName, canary.Namespace, err)
+		}
+		return nil
+	}
+
+	if len(routeTable.Spec.Routes) == 0 {
+		routeTable.Spec.Routes = []gatewayv1.Route{{Action: action}}
+	} else {
+		routeTable.Spec.Routes[0].Action = action
+	}
+
+	if _, err := gr.glooClient.GatewayV1().RouteTables(canary.Namespace).Update(context.TODO(), routeTable, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("RouteTable %s.%s update error: %w", apexName, canary.Namespace, err)
+	}
+	return nil
+}
+
 func (gr *GlooRouter) Finalize(canary *flaggerv1.Canary) error {
 	apexName, _, _ := canary.GetServiceNames()
 	canaryName := fmt.Sprintf("%s-canary-%v", apexName, canary.Spec.Service.Port)
 
+	routeTable, foundRouteTable, err := gr.getRouteTable(canary, apexName)
+	if err != nil {
+		return err
+	}
+	if foundRouteTable {
+		return gr.finalizeRouteTable(canary, routeTable, apexName, canaryName)
+	}
+
 	upstreamGroup, err := gr.glooClient.GlooV1().UpstreamGroups(canary.Namespace).Get(context.TODO(), apexName, metav1.GetOptions{})
 	if err != nil {
 		return fmt.Errorf("UpstreamGroup %s.%s query error: %w", apexName, canary.Namespace, err)
@@ -160,6 +746,42 @@ func (gr *GlooRouter) Finalize(canary *flaggerv1.Canary) error {
 	return nil
 }
 
+// finalizeRouteTable leaves the primary serving all traffic. The
+// reconcileRouteTable (A/B match) shape has a matched route pinned to the
+// canary ahead of a catch-all fallback, so removing just the canary
+// destination there would leave a live but destination-less matched
+// route; that shape collapses back to a single catch-all route instead.
+func (gr *GlooRouter) finalizeRouteTable(canary *flaggerv1.Canary, routeTable *gatewayv1.RouteTable, apexName, canaryName string) error {
+	if len(routeTable.Spec.Routes) == 0 {
+		return nil
+	}
+
+	if len(routeTable.Spec.Routes) > 1 || len(routeTable.Spec.Routes[0].Matchers) > 0 {
+		primaryName := glooPrimaryUpstreamName(canary, apexName)
+		routeTable.Spec.Routes = []gatewayv1.Route{{Action: singleDestinationAction(primaryName, canary.Namespace)}}
+	} else if routeTable.Spec.Routes[0].Action != nil {
+		action := routeTable.Spec.Routes[0].Action
+		// A rolled-back or promoted canary leaves no mirroring, retry,
+		// timeout, CORS or header manipulation residue behind.
+		action.Options = nil
+
+		if action.Destination != nil {
+			destinations := action.Destination.Destinations
+			for i, dst := range destinations {
+				if dst.Destination.Upstream.Name == canaryName {
+					action.Destination.Destinations = remove(destinations, i)
+					break
+				}
+			}
+		}
+	}
+
+	if _, err := gr.glooClient.GatewayV1().RouteTables(canary.Namespace).Update(context.TODO(), routeTable, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("RouteTable %s.%s update error: %w", apexName, canary.Namespace, err)
+	}
+	return nil
+}
+
 func remove(s [] gloov1.WeightedDestination, i int) []gloov1.WeightedDestination {
 	s[i] = s[len(s)-1]
 	return s[:len(s)-1]
@@ -0,0 +1,65 @@
+package router
+
+import (
+	"testing"
+
+	istiov1alpha3 "github.com/weaveworks/flagger/pkg/apis/istio/v1alpha3"
+)
+
+// TestReconcileRouteTableComposesMatchWithOptions guards against A/B header
+// matching silently dropping (or clobbering) the canary service's
+// retries/timeout/CORS/header options, since reconcileRouteTable used to
+// replace the whole Routes slice without ever calling buildRouteActionOptions.
+func TestReconcileRouteTableComposesMatchWithOptions(t *testing.T) {
+	c := newFakeClientset()
+	gr := newTestRouter(c)
+	canary := newTestCanary("test", "podinfo", 9898)
+	canary.Spec.Analysis.Match = []istiov1alpha3.HTTPMatchRequest{
+		{Headers: map[string]istiov1alpha3.StringMatch{"x-canary": {Exact: "always"}}},
+	}
+	canary.Spec.Service.Timeout = "5s"
+	canary.Spec.Service.CorsPolicy = &istiov1alpha3.CorsPolicy{AllowOrigin: []string{"*"}}
+	apexName := "podinfo"
+	canaryName := "podinfo-canary-9898"
+
+	if err := gr.reconcileRouteTable(canary, apexName, canaryName); err != nil {
+		t.Fatalf("reconcileRouteTable: %v", err)
+	}
+
+	routeTable, found, err := gr.getRouteTable(canary, apexName)
+	if err != nil || !found {
+		t.Fatalf("getRouteTable: found=%v err=%v", found, err)
+	}
+	if len(routeTable.Spec.Routes) != 2 {
+		t.Fatalf("got %d routes, want 2 (matched + fallback)", len(routeTable.Spec.Routes))
+	}
+	for i, route := range routeTable.Spec.Routes {
+		if route.Action == nil || route.Action.Options == nil {
+			t.Fatalf("route[%d] lost its route options: %+v", i, route)
+		}
+		if route.Action.Options.Timeout != "5s" {
+			t.Errorf("route[%d] got timeout %q, want 5s", i, route.Action.Options.Timeout)
+		}
+		if route.Action.Options.Cors == nil || len(route.Action.Options.Cors.AllowOrigin) != 1 {
+			t.Errorf("route[%d] lost its CORS policy: %+v", i, route.Action.Options.Cors)
+		}
+	}
+
+	// A later reconcile with the options cleared must not clobber the
+	// shape: the routes stay composed (matched + fallback) and the stale
+	// options are dropped rather than lingering.
+	canary.Spec.Service.Timeout = ""
+	canary.Spec.Service.CorsPolicy = nil
+	if err := gr.reconcileRouteTable(canary, apexName, canaryName); err != nil {
+		t.Fatalf("reconcileRouteTable (clear options): %v", err)
+	}
+	routeTable, found, err = gr.getRouteTable(canary, apexName)
+	if err != nil || !found {
+		t.Fatalf("getRouteTable after clearing options: found=%v err=%v", found, err)
+	}
+	for i, route := range routeTable.Spec.Routes {
+		if route.Action != nil && route.Action.Options != nil {
+			t.Errorf("route[%d] still carries stale options: %+v", i, route.Action.Options)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package router
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gatewayv1 "github.com/weaveworks/flagger/pkg/apis/gateway/v1"
+)
+
+func TestMergeRouteActionOptionsClearsStaleFieldsOnNil(t *testing.T) {
+	existing := &gatewayv1.RouteActionOptions{
+		Timeout: "5s",
+		Retries: &gatewayv1.RetryPolicy{NumRetries: 3},
+		Cors:    &gatewayv1.CorsPolicy{AllowOrigin: []string{"*"}},
+	}
+
+	merged := mergeRouteActionOptions(existing, nil)
+	if merged != nil {
+		t.Fatalf("expected nil options once retries/timeout/cors are unset and nothing else remains, got %+v", merged)
+	}
+
+	// A Shadowing option owned by the mirror stage must survive.
+	existing = &gatewayv1.RouteActionOptions{
+		Timeout:   "5s",
+		Shadowing: &gatewayv1.ShadowPolicy{Percentage: 10},
+	}
+	merged = mergeRouteActionOptions(existing, nil)
+	if merged == nil || merged.Shadowing == nil {
+		t.Fatalf("expected Shadowing to survive a nil merge, got %+v", merged)
+	}
+	if merged.Timeout != "" || merged.Retries != nil || merged.Cors != nil {
+		t.Fatalf("expected retries/timeout/cors cleared, got %+v", merged)
+	}
+}
+
+func TestFinalizeRouteTableCollapsesABShape(t *testing.T) {
+	c := newFakeClientset()
+	gr := newTestRouter(c)
+	canary := newTestCanary("test", "podinfo", 9898)
+	apexName := "podinfo"
+	canaryName := "podinfo-canary-9898"
+	primaryName := glooPrimaryUpstreamName(canary, apexName)
+
+	routeTable := &gatewayv1.RouteTable{
+		ObjectMeta: metav1.ObjectMeta{Name: apexName, Namespace: canary.Namespace},
+		Spec: gatewayv1.RouteTableSpec{
+			Routes: []gatewayv1.Route{
+				{
+					Matchers: []gatewayv1.Matcher{{Headers: []gatewayv1.HeaderMatcher{{Name: "x-canary", Value: "always"}}}},
+					Action:   singleDestinationAction(canaryName, canary.Namespace),
+				},
+				{Action: singleDestinationAction(primaryName, canary.Namespace)},
+			},
+		},
+	}
+	if _, err := c.GatewayV1().RouteTables(canary.Namespace).Create(context.TODO(), routeTable, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("seed RouteTable: %v", err)
+	}
+
+	if err := gr.finalizeRouteTable(canary, routeTable, apexName, canaryName); err != nil {
+		t.Fatalf("finalizeRouteTable: %v", err)
+	}
+
+	stored, found, err := gr.getRouteTable(canary, apexName)
+	if err != nil || !found {
+		t.Fatalf("getRouteTable after finalize: found=%v err=%v", found, err)
+	}
+	if len(stored.Spec.Routes) != 1 {
+		t.Fatalf("got %d routes after finalize, want a single collapsed catch-all route", len(stored.Spec.Routes))
+	}
+	route := stored.Spec.Routes[0]
+	if len(route.Matchers) != 0 {
+		t.Fatalf("expected the collapsed route to have no matchers, got %+v", route.Matchers)
+	}
+	if route.Action == nil || route.Action.Destination == nil || len(route.Action.Destination.Destinations) != 1 {
+		t.Fatalf("expected a single destination, got %+v", route.Action)
+	}
+	if route.Action.Destination.Destinations[0].Destination.Upstream.Name != primaryName {
+		t.Fatalf("expected the collapsed route to point at the primary upstream, got %+v", route.Action.Destination.Destinations[0])
+	}
+}
@@ -0,0 +1,53 @@
+package router
+
+import "testing"
+
+func TestMirrorRouteSetAndClear(t *testing.T) {
+	c := newFakeClientset()
+	gr := newTestRouter(c)
+	canary := newTestCanary("test", "podinfo", 9898)
+	canary.Spec.Analysis.MirrorWeight = 25
+	apexName := "podinfo"
+	canaryName := "podinfo-canary-9898"
+
+	if err := gr.setMirrorRoute(canary, apexName, canaryName); err != nil {
+		t.Fatalf("setMirrorRoute: %v", err)
+	}
+
+	routeTable, found, err := gr.getRouteTable(canary, apexName)
+	if err != nil || !found {
+		t.Fatalf("getRouteTable after mirror: found=%v err=%v", found, err)
+	}
+
+	primaryWeight, canaryWeight, mirrored, err := gr.getRouteTableWeights(canary, routeTable, apexName, canaryName)
+	if err != nil {
+		t.Fatalf("getRouteTableWeights: %v", err)
+	}
+	if !mirrored {
+		t.Fatalf("expected mirrored=true after setMirrorRoute")
+	}
+	if primaryWeight != 100 || canaryWeight != 0 {
+		t.Fatalf("got primary=%d canary=%d while mirrored, want 100/0", primaryWeight, canaryWeight)
+	}
+
+	// Returning to weighted routing (e.g. the canary moving past the
+	// mirror stage) must clear the leftover Shadowing option.
+	if err := gr.setRouteTableWeights(canary, routeTable, apexName, canaryName, 90, 10); err != nil {
+		t.Fatalf("setRouteTableWeights: %v", err)
+	}
+
+	routeTable, found, err = gr.getRouteTable(canary, apexName)
+	if err != nil || !found {
+		t.Fatalf("getRouteTable after unmirror: found=%v err=%v", found, err)
+	}
+	primaryWeight, canaryWeight, mirrored, err = gr.getRouteTableWeights(canary, routeTable, apexName, canaryName)
+	if err != nil {
+		t.Fatalf("getRouteTableWeights after unmirror: %v", err)
+	}
+	if mirrored {
+		t.Fatalf("expected mirrored=false after setRouteTableWeights")
+	}
+	if primaryWeight != 90 || canaryWeight != 10 {
+		t.Fatalf("got primary=%d canary=%d, want 90/10", primaryWeight, canaryWeight)
+	}
+}
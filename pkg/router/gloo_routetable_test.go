@@ -0,0 +1,161 @@
+package router
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	flaggerv1 "github.com/weaveworks/flagger/pkg/apis/flagger/v1beta1"
+	gatewayv1 "github.com/weaveworks/flagger/pkg/apis/gateway/v1"
+	gloov1 "github.com/weaveworks/flagger/pkg/apis/gloo/v1"
+)
+
+func newTestCanary(namespace, name string, port int32) *flaggerv1.Canary {
+	canary := &flaggerv1.Canary{}
+	canary.Namespace = namespace
+	canary.Name = name
+	canary.Spec.TargetRef.Name = name
+	canary.Spec.Service.Port = port
+	return canary
+}
+
+func newTestRouter(c *fakeClientset) *GlooRouter {
+	return &GlooRouter{glooClient: c}
+}
+
+func TestRouteTableModeSwitch(t *testing.T) {
+	c := newFakeClientset()
+	gr := newTestRouter(c)
+	canary := newTestCanary("test", "podinfo", 9898)
+	apexName := "podinfo"
+
+	_, found, err := gr.getRouteTable(canary, apexName)
+	if err != nil {
+		t.Fatalf("getRouteTable: %v", err)
+	}
+	if found {
+		t.Fatalf("expected no RouteTable yet, should stay on UpstreamGroup routing")
+	}
+
+	canaryName := "podinfo-canary-9898"
+	if err := gr.reconcileRouteTableWeights(canary, nil, false, apexName, canaryName); err != nil {
+		t.Fatalf("reconcileRouteTableWeights: %v", err)
+	}
+
+	routeTable, found, err := gr.getRouteTable(canary, apexName)
+	if err != nil {
+		t.Fatalf("getRouteTable: %v", err)
+	}
+	if !found {
+		t.Fatalf("expected a RouteTable to exist once reconciled, switching to RouteTable routing")
+	}
+	if len(routeTable.Spec.Routes) != 1 {
+		t.Fatalf("got %d routes, want 1", len(routeTable.Spec.Routes))
+	}
+}
+
+func TestRouteTableWeightsRoundTrip(t *testing.T) {
+	c := newFakeClientset()
+	gr := newTestRouter(c)
+	canary := newTestCanary("test", "podinfo", 9898)
+	apexName := "podinfo"
+	canaryName := "podinfo-canary-9898"
+	primaryName := glooPrimaryUpstreamName(canary, apexName)
+
+	routeTable := &gatewayv1.RouteTable{
+		ObjectMeta: metav1.ObjectMeta{Name: apexName, Namespace: canary.Namespace},
+		Spec: gatewayv1.RouteTableSpec{
+			Routes: []gatewayv1.Route{
+				{
+					Action: &gatewayv1.RouteAction{
+						Destination: &gatewayv1.MultiDestination{
+							Destinations: []gloov1.WeightedDestination{
+								{Destination: gloov1.Destination{Upstream: gloov1.ResourceRef{Name: primaryName, Namespace: canary.Namespace}}, Weight: 1000},
+								{Destination: gloov1.Destination{Upstream: gloov1.ResourceRef{Name: canaryName, Namespace: canary.Namespace}}, Weight: 0},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if _, err := c.GatewayV1().RouteTables(canary.Namespace).Create(context.TODO(), routeTable, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("seed RouteTable: %v", err)
+	}
+
+	if err := gr.setRouteTableWeights(canary, routeTable, apexName, canaryName, 60, 40); err != nil {
+		t.Fatalf("setRouteTableWeights: %v", err)
+	}
+
+	stored, found, err := gr.getRouteTable(canary, apexName)
+	if err != nil || !found {
+		t.Fatalf("getRouteTable after set: found=%v err=%v", found, err)
+	}
+
+	primaryWeight, canaryWeight, mirrored, err := gr.getRouteTableWeights(canary, stored, apexName, canaryName)
+	if err != nil {
+		t.Fatalf("getRouteTableWeights: %v", err)
+	}
+	if mirrored {
+		t.Fatalf("expected mirrored=false after a weighted set")
+	}
+	if primaryWeight != 60 || canaryWeight != 40 {
+		t.Fatalf("got primary=%d canary=%d, want 60/40", primaryWeight, canaryWeight)
+	}
+}
+
+// TestReconcileRouteTableWeightsCollapsesStaleABShape guards against a
+// RouteTable left in reconcileRouteTable's matched+fallback shape staying
+// pinned by header/cookie match forever once canary.Spec.Analysis.Match is
+// cleared and Reconcile starts taking the weighted RouteTable path instead.
+func TestReconcileRouteTableWeightsCollapsesStaleABShape(t *testing.T) {
+	c := newFakeClientset()
+	gr := newTestRouter(c)
+	canary := newTestCanary("test", "podinfo", 9898)
+	apexName := "podinfo"
+	canaryName := "podinfo-canary-9898"
+	primaryName := glooPrimaryUpstreamName(canary, apexName)
+
+	routeTable := &gatewayv1.RouteTable{
+		ObjectMeta: metav1.ObjectMeta{Name: apexName, Namespace: canary.Namespace},
+		Spec: gatewayv1.RouteTableSpec{
+			Routes: []gatewayv1.Route{
+				{
+					Matchers: []gatewayv1.Matcher{{Headers: []gatewayv1.HeaderMatcher{{Name: "x-canary", Value: "always"}}}},
+					Action:   singleDestinationAction(canaryName, canary.Namespace),
+				},
+				{Action: singleDestinationAction(primaryName, canary.Namespace)},
+			},
+		},
+	}
+	if _, err := c.GatewayV1().RouteTables(canary.Namespace).Create(context.TODO(), routeTable, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("seed RouteTable: %v", err)
+	}
+
+	if err := gr.reconcileRouteTableWeights(canary, routeTable, true, apexName, canaryName); err != nil {
+		t.Fatalf("reconcileRouteTableWeights: %v", err)
+	}
+
+	stored, found, err := gr.getRouteTable(canary, apexName)
+	if err != nil || !found {
+		t.Fatalf("getRouteTable: found=%v err=%v", found, err)
+	}
+	if len(stored.Spec.Routes) != 1 {
+		t.Fatalf("got %d routes, want a single collapsed weighted route", len(stored.Spec.Routes))
+	}
+	if len(stored.Spec.Routes[0].Matchers) != 0 {
+		t.Fatalf("expected the collapsed route to have no matchers, got %+v", stored.Spec.Routes[0].Matchers)
+	}
+
+	primaryWeight, canaryWeight, mirrored, err := gr.getRouteTableWeights(canary, stored, apexName, canaryName)
+	if err != nil {
+		t.Fatalf("getRouteTableWeights: %v", err)
+	}
+	if mirrored {
+		t.Fatalf("expected mirrored=false right after collapsing out of A/B mode")
+	}
+	if primaryWeight != 100 || canaryWeight != 0 {
+		t.Fatalf("got primary=%d canary=%d, want 100/0 for a freshly collapsed route", primaryWeight, canaryWeight)
+	}
+}
@@ -0,0 +1,63 @@
+package router
+
+import (
+	"testing"
+
+	gatewayv1 "github.com/weaveworks/flagger/pkg/apis/gateway/v1"
+	istiov1alpha3 "github.com/weaveworks/flagger/pkg/apis/istio/v1alpha3"
+)
+
+func TestRouteDeepCopyDoesNotAliasMatcherHeaders(t *testing.T) {
+	in := gatewayv1.Route{
+		Matchers: []gatewayv1.Matcher{
+			{Headers: []gatewayv1.HeaderMatcher{{Name: "x-canary", Value: "always"}}},
+		},
+	}
+
+	var out gatewayv1.Route
+	in.DeepCopyInto(&out)
+
+	out.Matchers[0].Headers[0].Value = "mutated"
+
+	if in.Matchers[0].Headers[0].Value != "always" {
+		t.Fatalf("mutating the copy's HeaderMatcher mutated the original too: %q", in.Matchers[0].Headers[0].Value)
+	}
+}
+
+func TestConvertMatch(t *testing.T) {
+	match := []istiov1alpha3.HTTPMatchRequest{
+		{
+			Headers: map[string]istiov1alpha3.StringMatch{
+				"x-canary": {Exact: "always"},
+				"cookie":   {Regex: "^(.*;\\s*)?session=canary(;.*)?$"},
+			},
+		},
+	}
+
+	matchers := convertMatch(match)
+	if len(matchers) != 1 {
+		t.Fatalf("got %d matchers, want 1", len(matchers))
+	}
+	if len(matchers[0].Headers) != 2 {
+		t.Fatalf("got %d header matchers, want 2 (cookie conditions are header matches)", len(matchers[0].Headers))
+	}
+
+	var sawCookie, sawHeader bool
+	for _, h := range matchers[0].Headers {
+		switch h.Name {
+		case "cookie":
+			sawCookie = true
+			if !h.Regex || h.Value != "^(.*;\\s*)?session=canary(;.*)?$" {
+				t.Errorf("cookie matcher not translated correctly: %+v", h)
+			}
+		case "x-canary":
+			sawHeader = true
+			if h.Regex || h.Value != "always" {
+				t.Errorf("header matcher not translated correctly: %+v", h)
+			}
+		}
+	}
+	if !sawCookie || !sawHeader {
+		t.Errorf("expected both a cookie and a plain header matcher, got %+v", matchers[0].Headers)
+	}
+}
@@ -0,0 +1,51 @@
+package router
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	istiov1alpha3 "github.com/weaveworks/flagger/pkg/apis/istio/v1alpha3"
+)
+
+func newTestRouterWithServices(canary *testCanaryServices) (*GlooRouter, *fakeClientset) {
+	kubeClient := kubefake.NewSimpleClientset(
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: canary.primary, Namespace: canary.namespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: canary.canary, Namespace: canary.namespace}},
+	)
+	glooClient := newFakeClientset()
+	return &GlooRouter{kubeClient: kubeClient, glooClient: glooClient}, glooClient
+}
+
+type testCanaryServices struct {
+	namespace, primary, canary string
+}
+
+// TestReconcileProvisionsUpstreamsInABMode guards against syncUpstream only
+// running on the plain UpstreamGroup path: the primary/canary Upstream CRs
+// must also be provisioned when the canary uses header/cookie A/B matching.
+func TestReconcileProvisionsUpstreamsInABMode(t *testing.T) {
+	canarySvc := &testCanaryServices{namespace: "test", primary: "podinfo-primary", canary: "podinfo-canary"}
+	gr, glooClient := newTestRouterWithServices(canarySvc)
+
+	canary := newTestCanary("test", "podinfo", 9898)
+	canary.Spec.Analysis.Match = []istiov1alpha3.HTTPMatchRequest{
+		{Headers: map[string]istiov1alpha3.StringMatch{"x-canary": {Exact: "always"}}},
+	}
+
+	if err := gr.Reconcile(canary); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	primaryUpstreamName := glooPrimaryUpstreamName(canary, "podinfo")
+	if _, err := glooClient.GlooV1().Upstreams("test").Get(context.TODO(), primaryUpstreamName, metav1.GetOptions{}); err != nil {
+		t.Errorf("primary Upstream %s not provisioned: %v", primaryUpstreamName, err)
+	}
+	canaryUpstreamName := "podinfo-canary-9898"
+	if _, err := glooClient.GlooV1().Upstreams("test").Get(context.TODO(), canaryUpstreamName, metav1.GetOptions{}); err != nil {
+		t.Errorf("canary Upstream %s not provisioned: %v", canaryUpstreamName, err)
+	}
+}